@@ -0,0 +1,114 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog
+
+import (
+	"bufio"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	defaultBufferSize    = 256 * 1024
+	defaultFlushInterval = 5 * time.Second
+)
+
+// BufferedWriter wraps a zapcore.WriteSyncer with an in-memory buffer that is
+// flushed when it fills up or on a periodic tick, whichever comes first.
+// This lets a high-throughput logger avoid a syscall per entry while
+// bounding how long an entry can sit unflushed.
+//
+// Callers that create a BufferedWriter directly, rather than through
+// Config.Buffered, are responsible for calling Stop when they are done with
+// it so the flush goroutine doesn't leak and the tail of the log isn't lost.
+type BufferedWriter struct {
+	mutex  sync.Mutex
+	writer *bufio.Writer
+	output zapcore.WriteSyncer
+
+	ticker  *time.Ticker
+	done    chan struct{}
+	stopped bool
+}
+
+// NewBufferedWriter wraps output in a BufferedWriter that flushes whenever
+// size bytes have accumulated, and also on every tick of a fixed interval
+// that a size-triggered flush does not reset. A non-positive size or
+// interval falls back to the package default.
+func NewBufferedWriter(output zapcore.WriteSyncer, size int, interval time.Duration) *BufferedWriter {
+	if size <= 0 {
+		size = defaultBufferSize
+	}
+	if interval <= 0 {
+		interval = defaultFlushInterval
+	}
+
+	b := &BufferedWriter{
+		writer: bufio.NewWriterSize(output, size),
+		output: output,
+		ticker: time.NewTicker(interval),
+		done:   make(chan struct{}),
+	}
+	go b.flushLoop()
+	return b
+}
+
+func (b *BufferedWriter) flushLoop() {
+	for {
+		select {
+		case <-b.ticker.C:
+			b.mutex.Lock()
+			b.writer.Flush()
+			b.mutex.Unlock()
+		case <-b.done:
+			return
+		}
+	}
+}
+
+// Write buffers p, flushing to the downstream writer once the buffer is
+// full. It satisfies io.Writer.
+func (b *BufferedWriter) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.writer.Write(p)
+}
+
+// Sync flushes any buffered bytes and then syncs the downstream writer.
+func (b *BufferedWriter) Sync() error {
+	b.mutex.Lock()
+	err := b.writer.Flush()
+	b.mutex.Unlock()
+
+	if syncErr := b.output.Sync(); err == nil {
+		err = syncErr
+	}
+	return err
+}
+
+// Stop flushes any buffered bytes, stops the periodic flush goroutine, and
+// syncs the downstream writer. It is safe to call Stop more than once.
+func (b *BufferedWriter) Stop() error {
+	b.mutex.Lock()
+	if b.stopped {
+		b.mutex.Unlock()
+		return nil
+	}
+	b.stopped = true
+	b.mutex.Unlock()
+
+	b.ticker.Stop()
+	close(b.done)
+	return b.Sync()
+}
+
+// Close is an alias for Stop so that BufferedWriter satisfies io.Closer.
+func (b *BufferedWriter) Close() error {
+	return b.Stop()
+}
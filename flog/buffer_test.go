@@ -0,0 +1,102 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog_test
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ipfn/go-flog/flog"
+)
+
+type syncBuffer struct {
+	mutex sync.Mutex
+	buf   bytes.Buffer
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.buf.Write(p)
+}
+
+func (s *syncBuffer) Sync() error { return nil }
+
+func (s *syncBuffer) String() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.buf.String()
+}
+
+func TestBufferedWriterFlushesOnSize(t *testing.T) {
+	out := &syncBuffer{}
+	bw := flog.NewBufferedWriter(out, 8, time.Hour)
+	defer bw.Stop()
+
+	_, err := bw.Write([]byte("0123456789"))
+	assert.NoError(t, err)
+	assert.Equal(t, "0123456789", out.String())
+}
+
+func TestBufferedWriterFlushesOnTick(t *testing.T) {
+	out := &syncBuffer{}
+	bw := flog.NewBufferedWriter(out, 1024, 10*time.Millisecond)
+	defer bw.Stop()
+
+	_, err := bw.Write([]byte("hello"))
+	assert.NoError(t, err)
+	assert.Empty(t, out.String())
+
+	assert.Eventually(t, func() bool {
+		return out.String() == "hello"
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestBufferedWriterSyncFlushes(t *testing.T) {
+	out := &syncBuffer{}
+	bw := flog.NewBufferedWriter(out, 1024, time.Hour)
+	defer bw.Stop()
+
+	_, err := bw.Write([]byte("hello"))
+	assert.NoError(t, err)
+	assert.NoError(t, bw.Sync())
+	assert.Equal(t, "hello", out.String())
+}
+
+func TestBufferedWriterStopIsIdempotent(t *testing.T) {
+	out := &syncBuffer{}
+	bw := flog.NewBufferedWriter(out, 1024, time.Hour)
+
+	_, err := bw.Write([]byte("hello"))
+	assert.NoError(t, err)
+	assert.NoError(t, bw.Stop())
+	assert.NoError(t, bw.Stop())
+	assert.Equal(t, "hello", out.String())
+}
+
+func TestLoggingBuffered(t *testing.T) {
+	out := &syncBuffer{}
+	logging, err := flog.New(flog.Config{
+		Format:        "%{message}",
+		Writer:        out,
+		Buffered:      true,
+		BufferSize:    1024,
+		FlushInterval: time.Hour,
+	})
+	assert.NoError(t, err)
+	defer logging.Stop()
+
+	logging.Logger("test-module").Warn("buffered message")
+	assert.Empty(t, out.String())
+
+	assert.NoError(t, logging.Sync())
+	assert.Equal(t, "buffered message\n", out.String())
+}
@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog
+
+import (
+	"io"
+	"time"
+)
+
+// Config holds the parameters used to construct or reconfigure a Logging
+// instance.
+type Config struct {
+	// Format is either "json", or a logging format specification compatible
+	// with the fabric "CONSOLE" formatter (for example, "%{color}%{time} %{message}").
+	// The zero value selects the default text format.
+	Format string
+
+	// LogSpec determines the log levels that are enabled. It consists of a
+	// default level optionally followed by per-module overrides, with
+	// segments separated by colons, for example: "info:chaincode=debug".
+	// The zero value is equivalent to "info".
+	LogSpec string
+
+	// Writer is the sink that formatted log entries are written to. The
+	// zero value selects os.Stderr. Ignored when Output is set.
+	Writer io.Writer
+
+	// Output, when non-empty, selects the sink to write to via a URL whose
+	// scheme names a registered SinkFactory, for example
+	// "file:///var/log/app.log?maxSize=100MB&maxBackups=7", "syslog://localhost:514?tag=app",
+	// "journald://", or "stderr://". It takes precedence over Writer.
+	Output string
+
+	// Buffered wraps the selected sink (Output, or else Writer) in a
+	// BufferedWriter so that log entries are
+	// batched rather than written with a syscall each, trading a small,
+	// bounded amount of latency for throughput. BufferSize and
+	// FlushInterval configure that wrapper; both fall back to the
+	// BufferedWriter package defaults when left at their zero value.
+	Buffered      bool
+	BufferSize    int
+	FlushInterval time.Duration
+
+	// Sampling caps the volume of log entries with the same level and
+	// message, so that a single noisy module can't drown the rest of the
+	// log. It applies as the default for every module; a module can be
+	// given its own rate in LogSpec with "module=level@initial/thereafter",
+	// for example "noisy-module=info@100/1000".
+	Sampling SamplingConfig
+
+	// OTelCore, when true, wraps the emitting core so that every entry
+	// logged through a context-aware logger (Logger.For or
+	// Logging.WithContext) carries trace_id and span_id fields for the
+	// span active on its context, and is additionally mirrored as a span
+	// event on that span.
+	OTelCore bool
+}
+
+// SamplingConfig configures log volume sampling; see Config.Sampling.
+type SamplingConfig struct {
+	// Initial is how many entries with a given level and message are let
+	// through, as-is, per Tick.
+	Initial int
+	// Thereafter is the sampling rate applied to entries beyond Initial:
+	// every Thereafter-th one is let through and the rest are dropped. Zero
+	// drops everything beyond Initial.
+	Thereafter int
+	// Tick is the window over which Initial and Thereafter are enforced.
+	// The zero value selects a one second tick.
+	Tick time.Duration
+
+	// OnDropped, if set, is called once per Tick for every module that has
+	// sampling engaged and has dropped at least one entry since the
+	// previous call, reporting how many were dropped. This gives operators
+	// visibility into when sampling is engaging without the dropped
+	// entries themselves reaching the log. Entries dropped by a logger
+	// derived with With (including Logger.For and Logging.WithContext)
+	// are sampled separately and are not counted in these reports.
+	OnDropped func(module string, dropped uint64)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Logger wraps the *zap.Logger returned by Logging.Logger, adding a For
+// method that attaches fields extracted from a context.
+type Logger struct {
+	*zap.Logger
+	logging *Logging
+}
+
+// For returns a child logger with fields extracted from ctx by every
+// ContextExtractor registered on the Logging that produced l applied, so
+// that a middleware that stashed correlation data on ctx once is reflected
+// in every log line without the caller threading a *zap.Logger around.
+func (l *Logger) For(ctx context.Context) *zap.Logger {
+	return l.Logger.With(l.logging.extractFields(ctx)...)
+}
+
+// ContextExtractor derives log fields, such as a request id, trace id,
+// tenant, or user, from a context.
+type ContextExtractor func(ctx context.Context) []zap.Field
+
+// RegisterContextExtractor adds extractor to the list consulted by Logger.For
+// and WithContext. Extractors run in registration order and their fields are
+// appended in that order. Keys are not deduplicated: if two extractors return
+// a field with the same key, both fields are emitted.
+func (s *Logging) RegisterContextExtractor(extractor ContextExtractor) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.extractors = append(s.extractors, extractor)
+}
+
+func (s *Logging) extractFields(ctx context.Context) []zap.Field {
+	s.mutex.RLock()
+	extractors := s.extractors
+	otelEnabled := s.otelEnabled
+	s.mutex.RUnlock()
+
+	var fields []zap.Field
+	if otelEnabled {
+		fields = append(fields, otelFields(ctx)...)
+	}
+	for _, extract := range extractors {
+		fields = append(fields, extract(ctx)...)
+	}
+	return fields
+}
+
+type loggerContextKey struct{}
+
+// NewContext returns a copy of ctx carrying logger, retrievable by
+// (*Logging).WithContext.
+func NewContext(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// WithContext returns the *zap.Logger stashed on ctx by NewContext, with
+// fields from every registered ContextExtractor applied. If ctx carries no
+// logger, s's unnamed root logger is used instead.
+func (s *Logging) WithContext(ctx context.Context) *zap.Logger {
+	logger, ok := ctx.Value(loggerContextKey{}).(*zap.Logger)
+	if !ok {
+		s.mutex.RLock()
+		logger = s.logger
+		s.mutex.RUnlock()
+	}
+	return logger.With(s.extractFields(ctx)...)
+}
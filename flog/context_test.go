@@ -0,0 +1,61 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/ipfn/go-flog/flog"
+)
+
+type requestIDKey struct{}
+
+func TestLoggerFor(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logging, err := flog.New(flog.Config{Format: "json", Writer: buf})
+	assert.NoError(t, err)
+
+	logging.RegisterContextExtractor(func(ctx context.Context) []zap.Field {
+		id, _ := ctx.Value(requestIDKey{}).(string)
+		if id == "" {
+			return nil
+		}
+		return []zap.Field{zap.String("request_id", id)}
+	})
+
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "req-1")
+	logging.Logger("test-module").For(ctx).Info("hello")
+
+	assert.Contains(t, buf.String(), `"request_id":"req-1"`)
+}
+
+func TestWithContext(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logging, err := flog.New(flog.Config{Format: "json", Writer: buf})
+	assert.NoError(t, err)
+
+	logging.RegisterContextExtractor(func(ctx context.Context) []zap.Field {
+		id, _ := ctx.Value(requestIDKey{}).(string)
+		if id == "" {
+			return nil
+		}
+		return []zap.Field{zap.String("request_id", id)}
+	})
+
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "req-2")
+	ctx = flog.NewContext(ctx, logging.Logger("test-module").Logger)
+
+	logging.WithContext(ctx).Warn("hi")
+
+	assert.Contains(t, buf.String(), `"name":"test-module"`)
+	assert.Contains(t, buf.String(), `"request_id":"req-2"`)
+}
@@ -0,0 +1,69 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog
+
+import "go.uber.org/zap/zapcore"
+
+// core is a zapcore.Core that gates entries by their logger name against a
+// set of module levels rather than a single static level. This is what lets
+// flog change the level of one module without affecting the others.
+type core struct {
+	levels  *moduleLevels
+	encoder zapcore.Encoder
+	output  zapcore.WriteSyncer
+}
+
+func newCore(encoder zapcore.Encoder, output zapcore.WriteSyncer, levels *moduleLevels) *core {
+	return &core{levels: levels, encoder: encoder, output: output}
+}
+
+// Enabled reports whether level is enabled by the most permissive level
+// currently configured for any module. The precise, per-module decision is
+// made in Check, which has access to the entry's logger name.
+func (c *core) Enabled(level zapcore.Level) bool {
+	lowest := c.levels.defaultOf()
+	for _, l := range c.levels.overrides() {
+		if l < lowest {
+			lowest = l
+		}
+	}
+	return level >= lowest
+}
+
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	clone := &core{levels: c.levels, encoder: c.encoder.Clone(), output: c.output}
+	for _, f := range fields {
+		f.AddTo(clone.encoder)
+	}
+	return clone
+}
+
+func (c *core) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if entry.Level >= c.levels.level(entry.LoggerName) {
+		return ce.AddCore(entry, c)
+	}
+	return ce
+}
+
+func (c *core) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.encoder.EncodeEntry(entry, fields)
+	if err != nil {
+		return err
+	}
+	defer buf.Free()
+
+	if leveled, ok := c.output.(LeveledSink); ok {
+		_, err = leveled.WriteLevel(entry.Level, buf.Bytes())
+		return err
+	}
+	_, err = c.output.Write(buf.Bytes())
+	return err
+}
+
+func (c *core) Sync() error {
+	return c.output.Sync()
+}
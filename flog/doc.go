@@ -0,0 +1,10 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package flog provides a small, module-scoped wrapper around zap that lets
+// applications configure logging once and then obtain per-module loggers
+// whose levels can be changed at runtime via a compact textual spec.
+package flog
@@ -0,0 +1,196 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// defaultFormat is used when Config.Format is empty.
+const defaultFormat = "%{color}%{time:2006-01-02T15:04:05.000Z0700} %{level:.4s} %{module} -> %{message}%{color:reset}"
+
+var formatDirective = regexp.MustCompile(`%{(color|id|module|shortfunc|level|time|message)(?::([^}]*))?}`)
+
+// segment is either a literal run of text or a directive parsed out of a
+// format string, e.g. "%{message}" or "%{time:2006-01-02}".
+type segment struct {
+	literal   string
+	directive string
+	arg       string
+}
+
+// parseFormat splits a format string into the literal and directive segments
+// that make it up. An empty format selects defaultFormat.
+func parseFormat(format string) ([]segment, error) {
+	if format == "" {
+		format = defaultFormat
+	}
+
+	var segments []segment
+	last := 0
+	for _, loc := range formatDirective.FindAllStringSubmatchIndex(format, -1) {
+		if loc[0] > last {
+			segments = append(segments, segment{literal: format[last:loc[0]]})
+		}
+
+		directive := format[loc[2]:loc[3]]
+		arg := ""
+		if loc[4] >= 0 {
+			arg = format[loc[4]:loc[5]]
+		}
+		if directive == "color" && arg != "" && arg != "bold" && arg != "reset" {
+			return nil, fmt.Errorf("invalid color option: %s", arg)
+		}
+
+		segments = append(segments, segment{directive: directive, arg: arg})
+		last = loc[1]
+	}
+	if last < len(format) {
+		segments = append(segments, segment{literal: format[last:]})
+	}
+	return segments, nil
+}
+
+// jsonEncoderConfig is used by the "json" format.
+var jsonEncoderConfig = zapcore.EncoderConfig{
+	MessageKey:     "msg",
+	LevelKey:       "level",
+	TimeKey:        "ts",
+	NameKey:        "name",
+	CallerKey:      "caller",
+	EncodeLevel:    zapcore.LowercaseLevelEncoder,
+	EncodeTime:     zapcore.EpochTimeEncoder,
+	EncodeDuration: zapcore.SecondsDurationEncoder,
+	EncodeCaller:   zapcore.ShortCallerEncoder,
+}
+
+// contextEncoderConfig has every entry-metadata key left blank, so the
+// encoder built from it renders nothing but the structured fields -
+// including ones baked in earlier by a With call, which live in the
+// encoder's own accumulated buffer rather than in the fields passed to
+// EncodeEntry. The template formatter renders the entry's own metadata
+// itself, via appendDirective, so it only wants this encoder for fields.
+var contextEncoderConfig = zapcore.EncoderConfig{SkipLineEnding: true}
+
+// newEncoder builds the zapcore.Encoder described by format, which is either
+// "json" or a template understood by parseFormat.
+func newEncoder(format string) (zapcore.Encoder, error) {
+	if format == "json" {
+		return zapcore.NewJSONEncoder(jsonEncoderConfig), nil
+	}
+
+	segments, err := parseFormat(format)
+	if err != nil {
+		return nil, err
+	}
+	return &templateEncoder{Encoder: zapcore.NewConsoleEncoder(contextEncoderConfig), segments: segments}, nil
+}
+
+// templateEncoder renders log entries using a fixed sequence of literal and
+// directive segments, e.g. "%{time} %{level} %{message}". Structured field
+// encoding (With, AddString, ...) is delegated to the embedded Encoder.
+type templateEncoder struct {
+	zapcore.Encoder
+	segments []segment
+}
+
+func (t *templateEncoder) Clone() zapcore.Encoder {
+	return &templateEncoder{Encoder: t.Encoder.Clone(), segments: t.segments}
+}
+
+func (t *templateEncoder) EncodeEntry(entry zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	line := buffer.NewPool().Get()
+	for _, s := range t.segments {
+		if s.directive == "" {
+			line.AppendString(s.literal)
+			continue
+		}
+		appendDirective(line, entry, s)
+	}
+
+	// Fields baked in by an earlier With call live in t.Encoder's own
+	// accumulated buffer, not in fields, so this must run even when fields
+	// is empty; contextEncoderConfig makes EncodeEntry render nothing but
+	// that context, and it comes back empty when there is none.
+	fieldBuf, err := t.Encoder.EncodeEntry(entry, fields)
+	if err != nil {
+		return nil, err
+	}
+	if fieldBuf.Len() > len("{}") {
+		line.AppendByte(' ')
+		line.Write(fieldBuf.Bytes())
+	}
+	fieldBuf.Free()
+
+	line.AppendString("\n")
+	return line, nil
+}
+
+func appendDirective(buf *buffer.Buffer, entry zapcore.Entry, s segment) {
+	switch s.directive {
+	case "message":
+		buf.AppendString(entry.Message)
+	case "level":
+		buf.AppendString(sprintfDirective(s.arg, entry.Level.CapitalString()))
+	case "module":
+		buf.AppendString(sprintfDirective(s.arg, entry.LoggerName))
+	case "shortfunc":
+		fn := entry.Caller.Function
+		if idx := strings.LastIndex(fn, "."); idx >= 0 {
+			fn = fn[idx+1:]
+		}
+		buf.AppendString(sprintfDirective(s.arg, fn))
+	case "time":
+		layout := s.arg
+		if layout == "" {
+			layout = "2006-01-02T15:04:05.000Z0700"
+		}
+		buf.AppendString(entry.Time.Format(layout))
+	case "id":
+		buf.AppendString(fmt.Sprintf("%d", entry.Time.UnixNano()))
+	case "color":
+		buf.AppendString(colorCode(s.arg, entry.Level))
+	}
+}
+
+// sprintfDirective applies arg as a fmt verb suffix (e.g. ".4s") to value
+// when present, otherwise returns value unchanged.
+func sprintfDirective(arg, value string) string {
+	if arg == "" {
+		return value
+	}
+	return fmt.Sprintf("%"+arg, value)
+}
+
+// colorCode returns the ANSI escape sequence for a color directive. An empty
+// arg selects a color based on the entry's level; "bold" and "reset" are
+// passed straight through. Any other argument is rejected by parseFormat
+// before this point is ever reached.
+func colorCode(arg string, level zapcore.Level) string {
+	switch arg {
+	case "reset":
+		return "\x1b[0m"
+	case "bold":
+		return "\x1b[1m"
+	default:
+		switch {
+		case level >= zapcore.ErrorLevel:
+			return "\x1b[31m"
+		case level >= zapcore.WarnLevel:
+			return "\x1b[33m"
+		case level >= zapcore.InfoLevel:
+			return "\x1b[34m"
+		default:
+			return "\x1b[36m"
+		}
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// LevelHandler returns an http.Handler that exposes s's log levels over
+// HTTP, giving operators the same "flip a running server to debug without a
+// restart" workflow as zap's AtomicLevel HTTP handler, but through flog's
+// module-scoped spec grammar.
+//
+// GET returns the current spec, default level, and per-module overrides as
+// JSON. PUT and POST activate a new spec, either supplied whole (as the
+// "spec" query parameter, or as the request body when "spec" is absent), or
+// as a single module override via the "module" and "level" query
+// parameters. Either form responds with the resulting levels, or a
+// structured {"error": "..."} body on failure.
+func LevelHandler(logging *Logging) http.Handler {
+	return &levelHandler{logging: logging}
+}
+
+type levelHandler struct {
+	logging *Logging
+}
+
+type levelsResponse struct {
+	Spec    string            `json:"spec"`
+	Default string            `json:"default"`
+	Modules map[string]string `json:"modules,omitempty"`
+}
+
+func (h *levelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.writeLevels(w, http.StatusOK)
+	case http.MethodPut, http.MethodPost:
+		h.activate(w, r)
+	default:
+		w.Header().Set("Allow", "GET, PUT, POST")
+		writeJSONError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s not allowed", r.Method))
+	}
+}
+
+func (h *levelHandler) activate(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if module := r.Form.Get("module"); module != "" {
+		levelText := r.Form.Get("level")
+		level, err := zapcore.ParseLevel(levelText)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("invalid level %q for module %q: %s", levelText, module, err))
+			return
+		}
+		h.logging.SetLevel(module, level)
+		h.writeLevels(w, http.StatusOK)
+		return
+	}
+
+	spec := r.Form.Get("spec")
+	if spec == "" {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		spec = strings.TrimSpace(string(body))
+	}
+
+	if err := h.logging.ActivateSpec(spec); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.writeLevels(w, http.StatusOK)
+}
+
+func (h *levelHandler) writeLevels(w http.ResponseWriter, status int) {
+	modules := map[string]string{}
+	for module, level := range h.logging.Levels() {
+		modules[module] = level.String()
+	}
+	writeJSON(w, status, levelsResponse{
+		Spec:    h.logging.Spec(),
+		Default: h.logging.DefaultLevel().String(),
+		Modules: modules,
+	})
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSONError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, errorResponse{Error: msg})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ipfn/go-flog/flog"
+)
+
+func TestLevelHandlerGet(t *testing.T) {
+	logging, err := flog.New(flog.Config{LogSpec: "warn:test-module=debug"})
+	assert.NoError(t, err)
+
+	handler := flog.LevelHandler(logging)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"spec":"warn:test-module=debug","default":"warn","modules":{"test-module":"debug"}}`, rec.Body.String())
+}
+
+func TestLevelHandlerActivateSpec(t *testing.T) {
+	logging, err := flog.New(flog.Config{})
+	assert.NoError(t, err)
+
+	handler := flog.LevelHandler(logging)
+	req := httptest.NewRequest(http.MethodPut, "/?spec="+url.QueryEscape("debug"), nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, zapcore.DebugLevel, logging.DefaultLevel())
+}
+
+func TestLevelHandlerSetModule(t *testing.T) {
+	logging, err := flog.New(flog.Config{})
+	assert.NoError(t, err)
+
+	handler := flog.LevelHandler(logging)
+	req := httptest.NewRequest(http.MethodPut, "/?module=foo&level=debug", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, zapcore.DebugLevel, logging.Levels()["foo"])
+}
+
+func TestLevelHandlerInvalidSpec(t *testing.T) {
+	logging, err := flog.New(flog.Config{})
+	assert.NoError(t, err)
+
+	handler := flog.LevelHandler(logging)
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("::=borken=::"))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "bad segment")
+}
@@ -0,0 +1,273 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Logging is a module-aware logging facility built on top of zap. It owns
+// the current encoding, module levels, and output, and hands out *zap.Logger
+// instances scoped by module name via Logger.
+//
+// A Logging is safe for concurrent use, including concurrent calls to
+// Logger, Apply, and SetWriter from other goroutines while loggers obtained
+// earlier continue to log.
+type Logging struct {
+	mutex sync.RWMutex
+
+	levels      *moduleLevels
+	writer      zapcore.WriteSyncer
+	buffered    *BufferedWriter
+	sink        stopper
+	core        *core
+	sampling    *samplingCore
+	otelEnabled bool
+	logger      *zap.Logger
+	extractors  []ContextExtractor
+}
+
+// stopper is implemented by sinks, such as the file sink's SIGHUP watcher,
+// that hold background resources needing an explicit teardown when they are
+// replaced or Logging is stopped.
+type stopper interface {
+	Stop()
+}
+
+// New constructs a Logging from config. It is equivalent to calling Apply on
+// a zero-value Logging.
+func New(config Config) (*Logging, error) {
+	s := &Logging{levels: newModuleLevels()}
+	if err := s.Apply(config); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Apply reconfigures the format, log spec, and output of s. Loggers obtained
+// from previous calls to Logger continue to work and pick up the new log
+// spec, since module levels are shared, but they keep the format and writer
+// that were current when they were obtained; only loggers obtained after
+// Apply returns use the new format and output.
+func (s *Logging) Apply(config Config) error {
+	if err := s.levels.activateSpec(config.LogSpec); err != nil {
+		return err
+	}
+
+	encoder, err := newEncoder(config.Format)
+	if err != nil {
+		return err
+	}
+
+	var syncer zapcore.WriteSyncer
+	if config.Output != "" {
+		syncer, err = newSink(config.Output)
+		if err != nil {
+			return err
+		}
+	} else {
+		writer := config.Writer
+		if writer == nil {
+			writer = os.Stderr
+		}
+		syncer = zapcore.AddSync(writer)
+	}
+
+	sink, _ := syncer.(stopper)
+
+	var buffered *BufferedWriter
+	if config.Buffered {
+		buffered = NewBufferedWriter(syncer, config.BufferSize, config.FlushInterval)
+		syncer = buffered
+	}
+
+	s.mutex.Lock()
+	oldBuffered := s.buffered
+	oldSampling := s.sampling
+	oldSink := s.sink
+	s.writer = syncer
+	s.buffered = buffered
+	s.sink = sink
+	s.core = newCore(encoder, s.writer, s.levels)
+
+	var zapCore zapcore.Core = s.core
+	s.sampling = nil
+	if samplingEnabled(s.levels, config.Sampling) {
+		s.sampling = newSamplingCore(s.core, s.levels, config.Sampling)
+		zapCore = s.sampling
+	}
+	s.otelEnabled = config.OTelCore
+	if s.otelEnabled {
+		zapCore = newOTelCore(zapCore)
+	}
+	s.logger = zap.New(zapCore, zap.AddCaller())
+	s.mutex.Unlock()
+
+	if oldBuffered != nil {
+		oldBuffered.Stop()
+	}
+	if oldSampling != nil {
+		oldSampling.Stop()
+	}
+	if oldSink != nil && oldSink != sink {
+		oldSink.Stop()
+	}
+	return nil
+}
+
+// Logger returns a Logger scoped to the named module. The returned logger
+// reflects future level changes (ActivateSpec, SetLevel, and the LogSpec of
+// a later Apply); it is not redirected by a later Apply or SetWriter and
+// keeps the format and writer that were current when it was obtained.
+func (s *Logging) Logger(name string) *Logger {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return &Logger{Logger: s.logger.Named(name), logging: s}
+}
+
+// SetWriter swaps the WriteSyncer that log entries, and direct calls to
+// Write, are sent to, and returns the previous one. If the writer being
+// replaced was a *BufferedWriter, its flush goroutine is stopped and its
+// buffer flushed before SetWriter returns; if it was a sink with background
+// resources of its own (such as the file sink's SIGHUP watcher), those are
+// stopped too.
+func (s *Logging) SetWriter(writer zapcore.WriteSyncer) zapcore.WriteSyncer {
+	sink, _ := writer.(stopper)
+
+	s.mutex.Lock()
+	old := s.writer
+	oldBuffered := s.buffered
+	oldSampling := s.sampling
+	oldSink := s.sink
+	s.writer = writer
+	buffered, _ := writer.(*BufferedWriter)
+	s.buffered = buffered
+	s.sink = sink
+	s.core = newCore(s.core.encoder, writer, s.levels)
+
+	var zapCore zapcore.Core = s.core
+	s.sampling = nil
+	if oldSampling != nil {
+		// Rebuild rather than mutate oldSampling.base in place: its
+		// perModule samplers were built around the old *core by value, so
+		// patching base wouldn't redirect them, and every module that had
+		// already logged would keep writing to the previous writer.
+		s.sampling = newSamplingCore(s.core, s.levels, SamplingConfig{
+			Initial:    oldSampling.global.initial,
+			Thereafter: oldSampling.global.thereafter,
+			Tick:       oldSampling.tick,
+			OnDropped:  oldSampling.onDropped,
+		})
+		zapCore = s.sampling
+	}
+	if s.otelEnabled {
+		zapCore = newOTelCore(zapCore)
+	}
+	s.logger = zap.New(zapCore, zap.AddCaller())
+	s.mutex.Unlock()
+
+	if oldBuffered != nil && oldBuffered != buffered {
+		oldBuffered.Stop()
+	}
+	if oldSampling != nil {
+		oldSampling.Stop()
+	}
+	if oldSink != nil && oldSink != sink {
+		oldSink.Stop()
+	}
+	return old
+}
+
+// Stop flushes and stops the current writer's background flusher, if it is
+// a *BufferedWriter, stops the sampling dropped-entry reporter, if
+// Config.Sampling.OnDropped was set, and stops the current sink's background
+// resources, if it has any (such as the file sink's SIGHUP watcher). Callers
+// in short-lived processes should call Stop before exit so the tail of the
+// log isn't lost.
+func (s *Logging) Stop() error {
+	s.mutex.RLock()
+	buffered := s.buffered
+	sink := s.sink
+	sampling := s.sampling
+	s.mutex.RUnlock()
+
+	if sampling != nil {
+		sampling.Stop()
+	}
+	if sink != nil {
+		sink.Stop()
+	}
+	if buffered == nil {
+		return nil
+	}
+	return buffered.Stop()
+}
+
+// Close is an alias for Stop so that Logging satisfies io.Closer.
+func (s *Logging) Close() error {
+	return s.Stop()
+}
+
+// Write implements io.Writer by forwarding directly to the current writer,
+// bypassing formatting. It lets Logging itself be used as, for example, the
+// writer a standard library *log.Logger sends output to.
+func (s *Logging) Write(p []byte) (int, error) {
+	s.mutex.RLock()
+	writer := s.writer
+	s.mutex.RUnlock()
+	return writer.Write(p)
+}
+
+// Sync flushes the current writer.
+func (s *Logging) Sync() error {
+	s.mutex.RLock()
+	writer := s.writer
+	s.mutex.RUnlock()
+	return writer.Sync()
+}
+
+// DefaultLevel returns the level applied to modules that have no explicit
+// override.
+func (s *Logging) DefaultLevel() zapcore.Level {
+	return s.levels.defaultOf()
+}
+
+// Levels returns a copy of the current per-module level overrides.
+func (s *Logging) Levels() map[string]zapcore.Level {
+	return s.levels.overrides()
+}
+
+// SetLevel sets the level for a single module without affecting the default
+// level or any other module's override.
+func (s *Logging) SetLevel(module string, level zapcore.Level) {
+	s.levels.setLevel(module, level)
+}
+
+// ActivateSpec parses spec and, if well formed, replaces the current default
+// level and module overrides with the ones it describes.
+func (s *Logging) ActivateSpec(spec string) error {
+	return s.levels.activateSpec(spec)
+}
+
+// Spec renders the current default level and module overrides back into
+// spec grammar, e.g. "info:chaincode=debug".
+func (s *Logging) Spec() string {
+	return s.levels.String()
+}
+
+// ResetLevels clears all module overrides and restores the default level to
+// info.
+func (s *Logging) ResetLevels() {
+	s.levels.reset()
+}
+
+var _ io.Writer = (*Logging)(nil)
@@ -116,3 +116,45 @@ func TestLoggingSetWriter(t *testing.T) {
 	err = logging.Sync()
 	assert.EqualError(t, err, "welp")
 }
+
+// stoppingWriteSyncer is a zapcore.WriteSyncer that also implements the
+// stopper interface sinks with background resources use (e.g. the file
+// sink's SIGHUP watcher), so that reconfiguration is observed to tear it
+// down rather than leak it.
+type stoppingWriteSyncer struct {
+	stopped int
+}
+
+func (w *stoppingWriteSyncer) Write(p []byte) (int, error) { return len(p), nil }
+func (w *stoppingWriteSyncer) Sync() error                 { return nil }
+func (w *stoppingWriteSyncer) Stop()                       { w.stopped++ }
+
+func TestLoggingStopsSinkOnReconfigure(t *testing.T) {
+	logging, err := flog.New(flog.Config{})
+	assert.NoError(t, err)
+
+	first := &stoppingWriteSyncer{}
+	logging.SetWriter(first)
+	assert.Equal(t, 0, first.stopped)
+
+	second := &stoppingWriteSyncer{}
+	logging.SetWriter(second)
+	assert.Equal(t, 1, first.stopped)
+	assert.Equal(t, 0, second.stopped)
+
+	assert.NoError(t, logging.Stop())
+	assert.Equal(t, 1, second.stopped)
+}
+
+func TestLoggingStopsSinkOnReconfigureWhenBuffered(t *testing.T) {
+	first := &stoppingWriteSyncer{}
+	logging, err := flog.New(flog.Config{Writer: first, Buffered: true})
+	assert.NoError(t, err)
+
+	second := &stoppingWriteSyncer{}
+	assert.NoError(t, logging.Apply(flog.Config{Writer: second, Buffered: true}))
+	assert.Equal(t, 1, first.stopped)
+
+	assert.NoError(t, logging.Stop())
+	assert.Equal(t, 1, second.stopped)
+}
@@ -0,0 +1,203 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// samplingSpec is a module's "@initial/thereafter" sampling override, parsed
+// out of a LogSpec segment such as "noisy-module=info@100/1000".
+type samplingSpec struct {
+	initial    int
+	thereafter int
+}
+
+// moduleLevels tracks the effective zap level for every module that has an
+// explicit override, along with the default level applied to modules that
+// don't. It is safe for concurrent use since it is read on every log call
+// and written whenever a spec is activated.
+type moduleLevels struct {
+	mutex        sync.RWMutex
+	defaultLevel zapcore.Level
+	levels       map[string]zapcore.Level
+	sampling     map[string]samplingSpec
+}
+
+func newModuleLevels() *moduleLevels {
+	return &moduleLevels{
+		defaultLevel: zapcore.InfoLevel,
+		levels:       map[string]zapcore.Level{},
+		sampling:     map[string]samplingSpec{},
+	}
+}
+
+// level returns the effective level for module, falling back to the default
+// level when module has no explicit override.
+func (m *moduleLevels) level(module string) zapcore.Level {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	if level, ok := m.levels[module]; ok {
+		return level
+	}
+	return m.defaultLevel
+}
+
+func (m *moduleLevels) defaultOf() zapcore.Level {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.defaultLevel
+}
+
+// overrides returns a copy of the per-module level overrides.
+func (m *moduleLevels) overrides() map[string]zapcore.Level {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	levels := make(map[string]zapcore.Level, len(m.levels))
+	for module, level := range m.levels {
+		levels[module] = level
+	}
+	return levels
+}
+
+func (m *moduleLevels) setLevel(module string, level zapcore.Level) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.levels[module] = level
+}
+
+// samplingFor returns module's sampling override, if any.
+func (m *moduleLevels) samplingFor(module string) (samplingSpec, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	spec, ok := m.sampling[module]
+	return spec, ok
+}
+
+// hasSamplingOverrides reports whether any module has a LogSpec sampling
+// override configured.
+func (m *moduleLevels) hasSamplingOverrides() bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return len(m.sampling) > 0
+}
+
+// reset clears all module overrides and restores the default level to info.
+func (m *moduleLevels) reset() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.defaultLevel = zapcore.InfoLevel
+	m.levels = map[string]zapcore.Level{}
+	m.sampling = map[string]samplingSpec{}
+}
+
+// activateSpec parses a log spec and, if it is well formed, replaces the
+// current default level and module overrides with the ones it describes.
+//
+// A spec is a colon-separated list of segments. A segment of the form
+// "LEVEL" sets the default level; a segment of the form
+// "MODULE[,MODULE...]=LEVEL" sets the level for the named modules. Empty
+// segments are ignored, which allows a spec like "::debug::" to parse. A
+// module segment's level may carry a "@INITIAL/THEREAFTER" sampling
+// override, e.g. "noisy-module=info@100/1000" (see SamplingConfig).
+func (m *moduleLevels) activateSpec(spec string) error {
+	defaultLevel := zapcore.InfoLevel
+	levels := map[string]zapcore.Level{}
+	sampling := map[string]samplingSpec{}
+
+	for _, segment := range strings.Split(spec, ":") {
+		if segment == "" {
+			continue
+		}
+
+		modules, levelText, isModuleSegment := strings.Cut(segment, "=")
+		if isModuleSegment {
+			if modules == "" || strings.Contains(levelText, "=") {
+				return fmt.Errorf("invalid logging specification '%s': bad segment '%s'", spec, segment)
+			}
+
+			levelText, rate, hasRate := strings.Cut(levelText, "@")
+			level, err := zapcore.ParseLevel(levelText)
+			if err != nil {
+				return fmt.Errorf("invalid logging specification '%s': bad segment '%s'", spec, segment)
+			}
+
+			var rateSpec samplingSpec
+			if hasRate {
+				rateSpec, err = parseSamplingRate(rate)
+				if err != nil {
+					return fmt.Errorf("invalid logging specification '%s': bad segment '%s'", spec, segment)
+				}
+			}
+
+			for _, module := range strings.Split(modules, ",") {
+				levels[module] = level
+				if hasRate {
+					sampling[module] = rateSpec
+				}
+			}
+			continue
+		}
+
+		level, err := zapcore.ParseLevel(segment)
+		if err != nil {
+			return fmt.Errorf("invalid logging specification '%s': bad segment '%s'", spec, segment)
+		}
+		defaultLevel = level
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.defaultLevel = defaultLevel
+	m.levels = levels
+	m.sampling = sampling
+	return nil
+}
+
+// parseSamplingRate parses the "INITIAL/THEREAFTER" half of a
+// "LEVEL@INITIAL/THEREAFTER" module segment.
+func parseSamplingRate(rate string) (samplingSpec, error) {
+	initialText, thereafterText, ok := strings.Cut(rate, "/")
+	if !ok {
+		return samplingSpec{}, fmt.Errorf("bad sampling rate '%s'", rate)
+	}
+	initial, err := strconv.Atoi(initialText)
+	if err != nil {
+		return samplingSpec{}, fmt.Errorf("bad sampling rate '%s'", rate)
+	}
+	thereafter, err := strconv.Atoi(thereafterText)
+	if err != nil {
+		return samplingSpec{}, fmt.Errorf("bad sampling rate '%s'", rate)
+	}
+	return samplingSpec{initial: initial, thereafter: thereafter}, nil
+}
+
+// String renders the current default level and module overrides back into
+// spec grammar, e.g. "info:chaincode=debug".
+func (m *moduleLevels) String() string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	modules := make([]string, 0, len(m.levels))
+	for module := range m.levels {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+
+	segments := make([]string, 0, len(modules)+1)
+	segments = append(segments, m.defaultLevel.String())
+	for _, module := range modules {
+		segments = append(segments, module+"="+m.levels[module].String())
+	}
+	return strings.Join(segments, ":")
+}
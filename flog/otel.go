@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// otelSpanFieldKey carries the active span across a Logger.With call so
+// that otelCore.Write can mirror the entry onto it. It is stripped before
+// the remaining fields reach the encoder, so it never appears in output.
+const otelSpanFieldKey = "flog.otel.span"
+
+func spanField(span trace.Span) zap.Field {
+	return zap.Field{Key: otelSpanFieldKey, Type: zapcore.SkipType, Interface: span}
+}
+
+// otelFields returns the trace_id/span_id fields for the span active on
+// ctx, plus the carrier field that lets otelCore mirror entries logged
+// through it onto that span, or nil if ctx carries no valid span.
+func otelFields(ctx context.Context) []zap.Field {
+	span := trace.SpanFromContext(ctx)
+	sc := span.SpanContext()
+	if !sc.IsValid() {
+		return nil
+	}
+	return []zap.Field{
+		zap.String("trace_id", sc.TraceID().String()),
+		zap.String("span_id", sc.SpanID().String()),
+		spanField(span),
+	}
+}
+
+// otelCore wraps the rest of the core chain so that every entry logged
+// through a span-carrying child logger (see otelFields) is additionally
+// recorded as an event on that span.
+type otelCore struct {
+	inner zapcore.Core
+	span  trace.Span
+}
+
+func newOTelCore(inner zapcore.Core) *otelCore {
+	return &otelCore{inner: inner}
+}
+
+func (c *otelCore) Enabled(level zapcore.Level) bool {
+	return c.inner.Enabled(level)
+}
+
+// Check runs entry through inner's own Check, including any module-level
+// gating and sampling it applies, and discards the result: inner.Write is
+// reached through otelCore.Write below rather than directly, so that span
+// mirroring happens exactly for the entries inner actually decided to keep.
+func (c *otelCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.inner.Check(entry, nil) == nil {
+		return ce
+	}
+	return ce.AddCore(entry, c)
+}
+
+func (c *otelCore) With(fields []zapcore.Field) zapcore.Core {
+	span := c.span
+	remaining := make([]zapcore.Field, 0, len(fields))
+	for _, f := range fields {
+		if f.Key == otelSpanFieldKey {
+			if s, ok := f.Interface.(trace.Span); ok {
+				span = s
+			}
+			continue
+		}
+		remaining = append(remaining, f)
+	}
+	return &otelCore{inner: c.inner.With(remaining), span: span}
+}
+
+func (c *otelCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	if c.span != nil && c.span.IsRecording() {
+		c.span.AddEvent(entry.Message, trace.WithAttributes(fieldsToAttributes(fields)...))
+	}
+	return c.inner.Write(entry, fields)
+}
+
+func (c *otelCore) Sync() error {
+	return c.inner.Sync()
+}
@@ -0,0 +1,126 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// otelBridge adapts a Logging to the standard library's slog.Handler, so
+// that code instrumented against slog (including OTel's slog bridge) logs
+// through the same module levels, format, and sinks as the rest of the
+// process.
+type otelBridge struct {
+	logging *Logging
+	groups  []string
+	attrs   []zap.Field
+}
+
+// NewOTelBridge returns an slog.Handler that forwards records to logging,
+// named after the handler's current group path, with module levels and
+// trace correlation (when Config.OTelCore is set) applied the same way as
+// any other *zap.Logger obtained from logging.
+func NewOTelBridge(logging *Logging) slog.Handler {
+	return &otelBridge{logging: logging}
+}
+
+// module returns the module name records are logged under: the handler's
+// group path joined with ".", the same separator attrField uses to
+// namespace attribute keys by group.
+func (b *otelBridge) module() string {
+	return strings.Join(b.groups, ".")
+}
+
+func (b *otelBridge) Enabled(_ context.Context, level slog.Level) bool {
+	return slogToZapLevel(level) >= b.logging.levels.level(b.module())
+}
+
+func (b *otelBridge) Handle(ctx context.Context, r slog.Record) error {
+	logger := b.logging.Logger(b.module()).For(ctx)
+
+	zapLevel := slogToZapLevel(r.Level)
+	ce := logger.Check(zapLevel, r.Message)
+	if ce == nil {
+		return nil
+	}
+
+	fields := make([]zap.Field, 0, len(b.attrs)+r.NumAttrs())
+	fields = append(fields, b.attrs...)
+	r.Attrs(func(a slog.Attr) bool {
+		fields = append(fields, b.attrField(a))
+		return true
+	})
+	ce.Write(fields...)
+	return nil
+}
+
+func (b *otelBridge) WithAttrs(attrs []slog.Attr) slog.Handler {
+	next := *b
+	next.attrs = append(append([]zap.Field{}, b.attrs...), b.attrsToFields(attrs)...)
+	return &next
+}
+
+func (b *otelBridge) WithGroup(name string) slog.Handler {
+	next := *b
+	next.groups = append(append([]string{}, b.groups...), name)
+	return &next
+}
+
+func (b *otelBridge) attrsToFields(attrs []slog.Attr) []zap.Field {
+	fields := make([]zap.Field, 0, len(attrs))
+	for _, a := range attrs {
+		fields = append(fields, b.attrField(a))
+	}
+	return fields
+}
+
+func (b *otelBridge) attrField(a slog.Attr) zap.Field {
+	key := a.Key
+	if len(b.groups) > 0 {
+		key = strings.Join(append(append([]string{}, b.groups...), a.Key), ".")
+	}
+
+	v := a.Value.Resolve()
+	switch v.Kind() {
+	case slog.KindString:
+		return zap.String(key, v.String())
+	case slog.KindInt64:
+		return zap.Int64(key, v.Int64())
+	case slog.KindUint64:
+		return zap.Uint64(key, v.Uint64())
+	case slog.KindFloat64:
+		return zap.Float64(key, v.Float64())
+	case slog.KindBool:
+		return zap.Bool(key, v.Bool())
+	case slog.KindDuration:
+		return zap.Duration(key, v.Duration())
+	case slog.KindTime:
+		return zap.Time(key, v.Time())
+	default:
+		return zap.Any(key, v.Any())
+	}
+}
+
+// slogToZapLevel maps an slog.Level to the zapcore.Level with the closest
+// matching severity; slog's finer-grained levels collapse onto zap's.
+func slogToZapLevel(level slog.Level) zapcore.Level {
+	switch {
+	case level < slog.LevelInfo:
+		return zapcore.DebugLevel
+	case level < slog.LevelWarn:
+		return zapcore.InfoLevel
+	case level < slog.LevelError:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.ErrorLevel
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ipfn/go-flog/flog"
+)
+
+func TestOTelBridgeHandlesRecords(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logging, err := flog.New(flog.Config{
+		Format: "%{level} %{message}",
+		Writer: buf,
+	})
+	assert.NoError(t, err)
+
+	logger := slog.New(flog.NewOTelBridge(logging))
+	logger.Info("started", slog.String("component", "worker"))
+
+	assert.Contains(t, buf.String(), "started")
+	assert.Contains(t, buf.String(), `"component": "worker"`)
+}
+
+func TestOTelBridgeRespectsLogSpec(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logging, err := flog.New(flog.Config{
+		Format:  "%{message}",
+		Writer:  buf,
+		LogSpec: "warn",
+	})
+	assert.NoError(t, err)
+
+	logger := slog.New(flog.NewOTelBridge(logging))
+	logger.Debug("quiet")
+	logger.Warn("loud")
+
+	assert.NotContains(t, buf.String(), "quiet")
+	assert.Contains(t, buf.String(), "loud")
+}
+
+func TestOTelBridgeWithAttrsAndGroup(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logging, err := flog.New(flog.Config{
+		Format: "%{message}",
+		Writer: buf,
+	})
+	assert.NoError(t, err)
+
+	logger := slog.New(flog.NewOTelBridge(logging)).
+		With(slog.String("request_id", "abc")).
+		WithGroup("http")
+	logger.Info("handled", slog.Int("status", 200))
+
+	assert.Contains(t, buf.String(), `"request_id": "abc"`)
+	assert.Contains(t, buf.String(), `"http.status": 200`)
+}
+
+func TestOTelBridgeGroupGatesByModuleLevel(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logging, err := flog.New(flog.Config{
+		Format:  "%{module} %{message}",
+		Writer:  buf,
+		LogSpec: "info:http=error",
+	})
+	assert.NoError(t, err)
+
+	logger := slog.New(flog.NewOTelBridge(logging)).WithGroup("http")
+	logger.Warn("quiet")
+	logger.Error("loud")
+
+	assert.NotContains(t, buf.String(), "quiet")
+	assert.Contains(t, buf.String(), "http loud")
+}
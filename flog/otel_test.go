@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/ipfn/go-flog/flog"
+)
+
+func spanContext(t *testing.T) trace.SpanContext {
+	t.Helper()
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	assert.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	assert.NoError(t, err)
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+}
+
+func TestLoggerForInjectsTraceFields(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logging, err := flog.New(flog.Config{
+		Format:   "%{message}",
+		Writer:   buf,
+		OTelCore: true,
+	})
+	assert.NoError(t, err)
+
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext(t))
+	logging.Logger("test-module").For(ctx).Info("hello")
+
+	assert.Contains(t, buf.String(), "hello")
+	assert.Contains(t, buf.String(), `"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736"`)
+	assert.Contains(t, buf.String(), `"span_id": "00f067aa0ba902b7"`)
+
+	buf.Reset()
+	logging.Logger("test-module").For(context.Background()).Info("no span")
+	assert.Equal(t, "no span\n", buf.String())
+}
+
+// recordingSpan is a minimal trace.Span fake that records whether an event
+// was added to it, for asserting that otelCore mirrors log entries as span
+// events on the active span.
+type recordingSpan struct {
+	trace.Span
+
+	sc     trace.SpanContext
+	events []string
+}
+
+func (s *recordingSpan) IsRecording() bool                  { return true }
+func (s *recordingSpan) SpanContext() trace.SpanContext     { return s.sc }
+func (s *recordingSpan) AddEvent(name string, _ ...trace.EventOption) {
+	s.events = append(s.events, name)
+}
+
+func TestOTelCoreMirrorsSpanEvents(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logging, err := flog.New(flog.Config{
+		Format:   "%{message}",
+		Writer:   buf,
+		OTelCore: true,
+	})
+	assert.NoError(t, err)
+
+	span := &recordingSpan{sc: spanContext(t)}
+	ctx := trace.ContextWithSpan(context.Background(), span)
+
+	logging.Logger("test-module").For(ctx).Info("hi", zap.String("user", "alice"))
+
+	assert.Equal(t, []string{"hi"}, span.events)
+	assert.True(t, strings.Contains(buf.String(), "hi"))
+}
+
+func TestOTelCorePreservesSampling(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logging, err := flog.New(flog.Config{
+		Format:   "%{message}",
+		Writer:   buf,
+		OTelCore: true,
+		Sampling: flog.SamplingConfig{
+			Initial:    2,
+			Thereafter: 1000000,
+			Tick:       time.Minute,
+		},
+	})
+	assert.NoError(t, err)
+
+	logger := logging.Logger("test-module")
+	for i := 0; i < 20; i++ {
+		logger.Info("tick")
+	}
+
+	assert.Equal(t, 2, strings.Count(buf.String(), "tick\n"))
+}
@@ -0,0 +1,173 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const defaultSamplingTick = time.Second
+
+// samplingCore wraps the module-gating core with a zapcore.Sampler for any
+// module that has a sampling rate configured, either as the global default
+// (SamplingConfig) or as a per-module "@initial/thereafter" LogSpec
+// override. Modules with no sampling configured pass straight through to
+// base, unsampled.
+type samplingCore struct {
+	base   *core
+	levels *moduleLevels
+	tick   time.Duration
+	global samplingSpec
+
+	mutex      sync.Mutex
+	perModule  map[string]zapcore.Core
+	dropCounts map[string]*atomic.Uint64
+
+	onDropped func(module string, dropped uint64)
+	ticker    *time.Ticker
+	done      chan struct{}
+	stopped   bool
+}
+
+func newSamplingCore(base *core, levels *moduleLevels, config SamplingConfig) *samplingCore {
+	tick := config.Tick
+	if tick <= 0 {
+		tick = defaultSamplingTick
+	}
+
+	c := &samplingCore{
+		base:       base,
+		levels:     levels,
+		tick:       tick,
+		global:     samplingSpec{initial: config.Initial, thereafter: config.Thereafter},
+		perModule:  map[string]zapcore.Core{},
+		dropCounts: map[string]*atomic.Uint64{},
+		onDropped:  config.OnDropped,
+	}
+	if c.onDropped != nil {
+		c.ticker = time.NewTicker(tick)
+		c.done = make(chan struct{})
+		go c.reportLoop()
+	}
+	return c
+}
+
+// samplingEnabled reports whether any sampling is configured at all, either
+// globally or for at least one module. Apply uses this to decide whether to
+// wrap the plain core with a samplingCore in the first place.
+func samplingEnabled(levels *moduleLevels, config SamplingConfig) bool {
+	if config.Initial > 0 || config.Thereafter > 0 {
+		return true
+	}
+	return levels.hasSamplingOverrides()
+}
+
+func (c *samplingCore) subcoreFor(module string) zapcore.Core {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if sub, ok := c.perModule[module]; ok {
+		return sub
+	}
+
+	spec, overridden := c.levels.samplingFor(module)
+	if !overridden {
+		if c.global.initial == 0 && c.global.thereafter == 0 {
+			c.perModule[module] = c.base
+			return c.base
+		}
+		spec = c.global
+	}
+
+	counter := &atomic.Uint64{}
+	c.dropCounts[module] = counter
+	hook := zapcore.SamplerHook(func(_ zapcore.Entry, decision zapcore.SamplingDecision) {
+		if decision&zapcore.LogDropped != 0 {
+			counter.Add(1)
+		}
+	})
+
+	sub := zapcore.NewSamplerWithOptions(c.base, c.tick, spec.initial, spec.thereafter, hook)
+	c.perModule[module] = sub
+	return sub
+}
+
+func (c *samplingCore) reportLoop() {
+	for {
+		select {
+		case <-c.ticker.C:
+			c.reportDropped()
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *samplingCore) reportDropped() {
+	c.mutex.Lock()
+	counts := make(map[string]*atomic.Uint64, len(c.dropCounts))
+	for module, counter := range c.dropCounts {
+		counts[module] = counter
+	}
+	c.mutex.Unlock()
+
+	for module, counter := range counts {
+		if dropped := counter.Swap(0); dropped > 0 {
+			c.onDropped(module, dropped)
+		}
+	}
+}
+
+// Stop stops the periodic dropped-entry report, if one was started. It is a
+// no-op when Config.Sampling.OnDropped was never set.
+func (c *samplingCore) Stop() {
+	if c.ticker == nil {
+		return
+	}
+	c.mutex.Lock()
+	if c.stopped {
+		c.mutex.Unlock()
+		return
+	}
+	c.stopped = true
+	c.mutex.Unlock()
+
+	c.ticker.Stop()
+	close(c.done)
+}
+
+func (c *samplingCore) Enabled(level zapcore.Level) bool {
+	return c.base.Enabled(level)
+}
+
+// With returns a samplingCore bound to a copy of base carrying fields. The
+// clone keeps its own sampling counters and does not start its own
+// dropped-entry reporter; Config.Sampling.OnDropped is only invoked by the
+// root samplingCore built in Apply.
+func (c *samplingCore) With(fields []zapcore.Field) zapcore.Core {
+	return newSamplingCore(c.base.With(fields).(*core), c.levels, SamplingConfig{
+		Initial:    c.global.initial,
+		Thereafter: c.global.thereafter,
+		Tick:       c.tick,
+	})
+}
+
+func (c *samplingCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return c.subcoreFor(entry.LoggerName).Check(entry, ce)
+}
+
+func (c *samplingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.base.Write(entry, fields)
+}
+
+func (c *samplingCore) Sync() error {
+	return c.base.Sync()
+}
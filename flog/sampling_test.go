@@ -0,0 +1,122 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ipfn/go-flog/flog"
+)
+
+func TestSamplingCapsVolume(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logging, err := flog.New(flog.Config{
+		Format: "%{message}",
+		Writer: buf,
+		Sampling: flog.SamplingConfig{
+			Initial:    2,
+			Thereafter: 5,
+			Tick:       time.Minute,
+		},
+	})
+	assert.NoError(t, err)
+
+	logger := logging.Logger("test-module")
+	for i := 0; i < 12; i++ {
+		logger.Info("tick")
+	}
+
+	lines := strings.Count(buf.String(), "tick\n")
+	// first 2 pass, then every 5th of the remaining 10 (the 5th and 10th) passes: 2 + 2 = 4.
+	assert.Equal(t, 4, lines)
+}
+
+func TestSamplingPerModuleLogSpecOverride(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logging, err := flog.New(flog.Config{
+		Format:  "%{module} %{message}",
+		Writer:  buf,
+		LogSpec: "info:noisy=info@1/0:quiet=info",
+		Sampling: flog.SamplingConfig{
+			Tick: time.Minute,
+		},
+	})
+	assert.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		logging.Logger("noisy").Info("tick")
+		logging.Logger("quiet").Info("tick")
+	}
+
+	assert.Equal(t, 1, strings.Count(buf.String(), "noisy tick"))
+	assert.Equal(t, 5, strings.Count(buf.String(), "quiet tick"))
+}
+
+func TestSamplingSetWriterRedirectsOutput(t *testing.T) {
+	first := &bytes.Buffer{}
+	logging, err := flog.New(flog.Config{
+		Format: "%{message}",
+		Writer: first,
+		Sampling: flog.SamplingConfig{
+			Initial:    1,
+			Thereafter: 1,
+			Tick:       time.Minute,
+		},
+	})
+	assert.NoError(t, err)
+
+	// Log from test-module before SetWriter, so that its per-module sampler
+	// is already cached, then again after: the entry logged after SetWriter
+	// must land on the new writer even though the module's sampler was
+	// cached before it, not just for modules logged for the first time
+	// after SetWriter.
+	logging.Logger("test-module").Info("before")
+
+	second := &bytes.Buffer{}
+	logging.SetWriter(zapcore.AddSync(second))
+	logging.Logger("test-module").Info("after")
+
+	assert.Equal(t, "before\n", first.String())
+	assert.Equal(t, "after\n", second.String())
+}
+
+func TestSamplingReportsDropped(t *testing.T) {
+	buf := &bytes.Buffer{}
+	dropped := make(chan uint64, 8)
+	logging, err := flog.New(flog.Config{
+		Format: "%{message}",
+		Writer: buf,
+		Sampling: flog.SamplingConfig{
+			Initial:    1,
+			Thereafter: 0,
+			Tick:       10 * time.Millisecond,
+			OnDropped: func(module string, n uint64) {
+				dropped <- n
+			},
+		},
+	})
+	assert.NoError(t, err)
+	defer logging.Stop()
+
+	logger := logging.Logger("test-module")
+	logger.Info("tick")
+	logger.Info("tick")
+	logger.Info("tick")
+
+	select {
+	case n := <-dropped:
+		assert.GreaterOrEqual(t, n, uint64(1))
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for dropped report")
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// SinkFactory constructs a zapcore.WriteSyncer from a parsed sink URL, such
+// as "file:///var/log/app.log?maxSize=100MB&maxBackups=7" or "syslog://localhost:514?tag=app".
+type SinkFactory interface {
+	NewSink(u *url.URL) (zapcore.WriteSyncer, error)
+}
+
+// SinkFactoryFunc adapts a function to a SinkFactory.
+type SinkFactoryFunc func(u *url.URL) (zapcore.WriteSyncer, error)
+
+// NewSink calls f.
+func (f SinkFactoryFunc) NewSink(u *url.URL) (zapcore.WriteSyncer, error) { return f(u) }
+
+// LeveledSink is implemented by sinks that can map a zap level to a native
+// destination-specific priority, such as syslog or the systemd journal. When
+// a core's output implements LeveledSink, WriteLevel is used in place of
+// Write so the mapping is applied on every entry.
+type LeveledSink interface {
+	zapcore.WriteSyncer
+	WriteLevel(level zapcore.Level, p []byte) (int, error)
+}
+
+var sinkRegistry = struct {
+	mutex     sync.RWMutex
+	factories map[string]SinkFactory
+}{factories: map[string]SinkFactory{}}
+
+// RegisterSink makes factory available for sink URLs with the given scheme.
+// It is typically called from an init function. Registering a scheme that
+// is already registered replaces the existing factory.
+func RegisterSink(scheme string, factory SinkFactory) {
+	sinkRegistry.mutex.Lock()
+	defer sinkRegistry.mutex.Unlock()
+	sinkRegistry.factories[scheme] = factory
+}
+
+// newSink parses rawURL and constructs the zapcore.WriteSyncer described by
+// it using the SinkFactory registered for its scheme.
+func newSink(rawURL string) (zapcore.WriteSyncer, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sink url %q: %w", rawURL, err)
+	}
+
+	sinkRegistry.mutex.RLock()
+	factory, ok := sinkRegistry.factories[u.Scheme]
+	sinkRegistry.mutex.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no sink registered for scheme %q", u.Scheme)
+	}
+
+	return factory.NewSink(u)
+}
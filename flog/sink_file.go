@@ -0,0 +1,217 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func init() {
+	RegisterSink("file", SinkFactoryFunc(newFileSink))
+}
+
+// rotatingFile is a zapcore.WriteSyncer that writes to a file, rotating it
+// (renaming the current file aside and opening a fresh one) once it reaches
+// maxSize, and pruning backups beyond maxBackups. It also reopens the file
+// on SIGHUP, so an external log rotator using the traditional
+// rename-then-signal convention works without flog-specific support.
+type rotatingFile struct {
+	mutex sync.Mutex
+	path  string
+
+	maxSize    int64
+	maxBackups int
+
+	file *os.File
+	size int64
+
+	sighup  chan os.Signal
+	done    chan struct{}
+	stopped bool
+}
+
+func newFileSink(u *url.URL) (zapcore.WriteSyncer, error) {
+	path := u.Path
+	if path == "" {
+		path = u.Opaque
+	}
+	if path == "" {
+		return nil, fmt.Errorf("file sink requires a path, got %q", u.String())
+	}
+
+	maxSize, err := parseSize(u.Query().Get("maxSize"))
+	if err != nil {
+		return nil, err
+	}
+
+	maxBackups := 0
+	if v := u.Query().Get("maxBackups"); v != "" {
+		maxBackups, err = strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maxBackups %q: %w", v, err)
+		}
+	}
+
+	f := &rotatingFile{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	f.watchSIGHUP()
+	return f, nil
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open log file %q: %w", f.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("stat log file %q: %w", f.path, err)
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.maxSize > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	backup := fmt.Sprintf("%s.%s", f.path, time.Now().UTC().Format("20060102T150405.000000000Z"))
+	if err := os.Rename(f.path, backup); err != nil {
+		return err
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	f.pruneBackups()
+	return nil
+}
+
+// pruneBackups removes the oldest rotated files beyond maxBackups. It relies
+// on the lexically-sortable timestamp format used by rotate.
+func (f *rotatingFile) pruneBackups() {
+	if f.maxBackups <= 0 {
+		return
+	}
+	backups, err := filepath.Glob(f.path + ".*")
+	if err != nil || len(backups) <= f.maxBackups {
+		return
+	}
+	sort.Strings(backups)
+	for _, stale := range backups[:len(backups)-f.maxBackups] {
+		os.Remove(stale)
+	}
+}
+
+// watchSIGHUP reopens the file whenever the process receives SIGHUP, so that
+// an external rotator that renames the file and signals the process is
+// picked up without a restart.
+func (f *rotatingFile) watchSIGHUP() {
+	f.sighup = make(chan os.Signal, 1)
+	f.done = make(chan struct{})
+	signal.Notify(f.sighup, syscall.SIGHUP)
+
+	go func() {
+		for {
+			select {
+			case <-f.sighup:
+				f.mutex.Lock()
+				f.file.Close()
+				f.open()
+				f.mutex.Unlock()
+			case <-f.done:
+				signal.Stop(f.sighup)
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops watching for SIGHUP. It does not close the underlying file,
+// since log writes may still be in flight. Stop is idempotent.
+func (f *rotatingFile) Stop() {
+	f.mutex.Lock()
+	if f.stopped {
+		f.mutex.Unlock()
+		return
+	}
+	f.stopped = true
+	f.mutex.Unlock()
+
+	close(f.done)
+}
+
+func (f *rotatingFile) Sync() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.file.Sync()
+}
+
+// parseSize parses a byte count with an optional KB/MB/GB suffix, e.g.
+// "100MB". An empty string means no size limit.
+func parseSize(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	units := []struct {
+		suffix string
+		mult   int64
+	}{
+		{"KB", 1 << 10},
+		{"MB", 1 << 20},
+		{"GB", 1 << 30},
+		{"B", 1},
+	}
+
+	upper := strings.ToUpper(s)
+	for _, unit := range units {
+		if strings.HasSuffix(upper, unit.suffix) {
+			n, err := strconv.ParseFloat(strings.TrimSpace(s[:len(s)-len(unit.suffix)]), 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid size %q", s)
+			}
+			return int64(n * float64(unit.mult)), nil
+		}
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return n, nil
+}
@@ -0,0 +1,43 @@
+//go:build linux
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog_test
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ipfn/go-flog/flog"
+)
+
+func TestJournaldSink(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "journal.socket")
+	listener, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	logging, err := flog.New(flog.Config{
+		Format: "%{message}",
+		Output: "journald://" + socketPath + "?tag=test-app",
+	})
+	assert.NoError(t, err)
+
+	logging.Logger("test-module").Error("boom")
+
+	buf := make([]byte, 4096)
+	n, err := listener.Read(buf)
+	assert.NoError(t, err)
+
+	datagram := string(buf[:n])
+	assert.Contains(t, datagram, "MESSAGE=boom")
+	assert.Contains(t, datagram, "SYSLOG_IDENTIFIER=test-app")
+	assert.Contains(t, datagram, "PRIORITY=3")
+}
@@ -0,0 +1,27 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog
+
+import (
+	"net/url"
+	"os"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func init() {
+	RegisterSink("stderr", SinkFactoryFunc(newStderrSink))
+	RegisterSink("stdout", SinkFactoryFunc(newStdoutSink))
+}
+
+func newStderrSink(*url.URL) (zapcore.WriteSyncer, error) {
+	return zapcore.Lock(os.Stderr), nil
+}
+
+func newStdoutSink(*url.URL) (zapcore.WriteSyncer, error) {
+	return zapcore.Lock(os.Stdout), nil
+}
@@ -0,0 +1,82 @@
+//go:build !windows && !plan9
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog
+
+import (
+	"log/syslog"
+	"net/url"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func init() {
+	RegisterSink("syslog", SinkFactoryFunc(newSyslogSink))
+}
+
+// newSyslogSink builds a sink from a URL such as "syslog://localhost:514?tag=app"
+// or, with no host, "syslog://?tag=app" to use the local syslog daemon.
+func newSyslogSink(u *url.URL) (zapcore.WriteSyncer, error) {
+	tag := u.Query().Get("tag")
+	priority := syslog.LOG_INFO | syslog.LOG_USER
+
+	var w *syslog.Writer
+	var err error
+	if u.Host == "" {
+		w, err = syslog.New(priority, tag)
+	} else {
+		network := u.Query().Get("network")
+		if network == "" {
+			network = "udp"
+		}
+		w, err = syslog.Dial(network, u.Host, priority, tag)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &syslogSink{w: w}, nil
+}
+
+// syslogSink implements LeveledSink so that each entry is sent at the
+// syslog severity matching its zap level, rather than a single fixed
+// priority for every message.
+type syslogSink struct {
+	w *syslog.Writer
+}
+
+func (s *syslogSink) Write(p []byte) (int, error) {
+	return s.WriteLevel(zapcore.InfoLevel, p)
+}
+
+func (s *syslogSink) WriteLevel(level zapcore.Level, p []byte) (int, error) {
+	msg := strings.TrimSuffix(string(p), "\n")
+
+	var err error
+	switch {
+	case level >= zapcore.DPanicLevel:
+		err = s.w.Crit(msg)
+	case level >= zapcore.ErrorLevel:
+		err = s.w.Err(msg)
+	case level >= zapcore.WarnLevel:
+		err = s.w.Warning(msg)
+	case level >= zapcore.InfoLevel:
+		err = s.w.Info(msg)
+	default:
+		err = s.w.Debug(msg)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (s *syslogSink) Sync() error {
+	return nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flog_test
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ipfn/go-flog/flog"
+)
+
+func TestRegisterSinkAndApplyOutput(t *testing.T) {
+	flog.RegisterSink("memtest", flog.SinkFactoryFunc(func(u *url.URL) (zapcore.WriteSyncer, error) {
+		return zapNopSyncer{}, nil
+	}))
+
+	logging, err := flog.New(flog.Config{Format: "%{message}", Output: "memtest://whatever"})
+	assert.NoError(t, err)
+	logging.Logger("test-module").Info("hello")
+}
+
+type zapNopSyncer struct{}
+
+func (zapNopSyncer) Write(p []byte) (int, error) { return len(p), nil }
+func (zapNopSyncer) Sync() error                 { return nil }
+
+func TestFileSinkRotatesBySize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	logging, err := flog.New(flog.Config{
+		Format: "%{message}",
+		Output: "file://" + path + "?maxSize=16&maxBackups=1",
+	})
+	assert.NoError(t, err)
+
+	logger := logging.Logger("test-module")
+	logger.Info("0123456789")
+	logger.Info("0123456789")
+	logger.Info("0123456789")
+	assert.NoError(t, logging.Sync())
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, len(entries), 2, "expected the log to have rotated into at least one backup")
+}
+
+func TestFileSinkRejectsMissingPath(t *testing.T) {
+	_, err := flog.New(flog.Config{Output: "file://"})
+	assert.Error(t, err)
+}